@@ -0,0 +1,84 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// newBuildCommand builds the "build" cobra command, which loads a
+// declarative multi-target manifest and runs every target it describes.
+func newBuildCommand(flags *CommonFlags) *cobra.Command {
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "build",
+		Short: "Build every target described by a fyne-cross manifest",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			def, err := LoadDefinition(file)
+			if err != nil {
+				return err
+			}
+
+			// Only flags the user actually passed may override the
+			// manifest: flags still holds newCommonFlags' cwd/home-derived
+			// defaults (AppID, Icon, Output, CacheDir, ...), which would
+			// otherwise always look "set" to applyFlagOverrides and
+			// permanently shadow the manifest's values.
+			targets, err := def.Expand(explicitCommonFlags(cmd.Flags(), flags))
+			if err != nil {
+				return err
+			}
+
+			results := make([]*BuildResult, 0, len(targets))
+			for _, t := range targets {
+				targetResults, err := runManifestTarget(t)
+				if err != nil {
+					return fmt.Errorf("target %q: %w", t.Platform, err)
+				}
+				results = append(results, targetResults...)
+			}
+			return emitResults(cmd.OutOrStdout(), flags, results)
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "fyne-cross.yaml", "Path to the manifest file")
+	_ = cmd.MarkFlagFilename("file", "yaml", "yml", "toml")
+
+	return cmd
+}
+
+// runManifestTarget executes a single expanded target, once per declared
+// arch (or once with the runner's default arch if none was set). It is a
+// package variable so tests can swap it out without invoking a real build;
+// in the built binary it delegates to the same runPlatform dispatch table
+// used to run "fyne-cross linux", "fyne-cross windows", and so on.
+var runManifestTarget = func(t *ExpandedTarget) ([]*BuildResult, error) {
+	run, ok := runPlatform[t.Platform]
+	if !ok {
+		return nil, fmt.Errorf("no runner registered for platform %q", t.Platform)
+	}
+
+	archs := t.Arch
+	if len(archs) == 0 {
+		archs = []string{""}
+	}
+
+	meta := TargetMetadata{
+		Certificate: t.Certificate,
+		Profile:     t.Profile,
+		Category:    t.Category,
+	}
+
+	results := make([]*BuildResult, 0, len(archs))
+	for _, arch := range archs {
+		meta.Arch = arch
+		result, err := run(t.Flags, ".", meta)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
@@ -0,0 +1,101 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestBuildCommandUsesManifestValuesEndToEnd builds the real command tree
+// (NewRootCommand -> "build" -> Expand) with runManifestTarget replaced by a
+// stub that copies the target's fields into its BuildResult, and runs
+// "build -f <manifest> --format json" against a manifest whose AppID, icon,
+// output and signing/packaging fields differ from the CLI's cwd-derived
+// defaults. It guards against both bugs the manifest merge has had:
+// CommonFlags defaults silently shadowing the manifest, and
+// Certificate/Category being parsed but never reaching the target handed
+// to runManifestTarget.
+func TestBuildCommandUsesManifestValuesEndToEnd(t *testing.T) {
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "fyne-cross.yaml")
+	manifest := `common:
+  app-id: manifest.app.id
+  icon: manifest-icon.png
+targets:
+  - platform: android
+    output: manifest-output
+    certificate: release.keystore
+    category: games
+`
+	if err := os.WriteFile(manifestPath, []byte(manifest), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	originalRunManifestTarget := runManifestTarget
+	defer func() { runManifestTarget = originalRunManifestTarget }()
+
+	var captured *ExpandedTarget
+	runManifestTarget = func(target *ExpandedTarget) ([]*BuildResult, error) {
+		captured = target
+		return []*BuildResult{{
+			Target:      target.Platform,
+			Arch:        "arm64",
+			OutputPath:  target.Flags.Output,
+			AppID:       target.Flags.AppID,
+			Icon:        target.Flags.Icon,
+			Certificate: target.Certificate,
+			Category:    target.Category,
+		}}, nil
+	}
+
+	root, err := NewRootCommand()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var out bytes.Buffer
+	root.SetOut(&out)
+	root.SetErr(&out)
+	root.SetArgs([]string{"build", "-f", manifestPath, "--format", "json"})
+
+	if err := root.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if captured == nil {
+		t.Fatal("runManifestTarget was never called")
+	}
+	if captured.Certificate != "release.keystore" {
+		t.Errorf("ExpandedTarget.Certificate = %q, want %q", captured.Certificate, "release.keystore")
+	}
+	if captured.Category != "games" {
+		t.Errorf("ExpandedTarget.Category = %q, want %q", captured.Category, "games")
+	}
+
+	var results []*BuildResult
+	if err := json.Unmarshal(out.Bytes(), &results); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, out.String())
+	}
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+
+	got := results[0]
+	if got.AppID != "manifest.app.id" {
+		t.Errorf("AppID = %q, want manifest value %q (not the cwd-derived CLI default)", got.AppID, "manifest.app.id")
+	}
+	if got.Icon != "manifest-icon.png" {
+		t.Errorf("Icon = %q, want manifest value %q (not the default Icon.png path)", got.Icon, "manifest-icon.png")
+	}
+	if got.OutputPath != "manifest-output" {
+		t.Errorf("OutputPath = %q, want manifest value %q", got.OutputPath, "manifest-output")
+	}
+	if got.Certificate != "release.keystore" {
+		t.Errorf("Certificate = %q, want %q", got.Certificate, "release.keystore")
+	}
+	if got.Category != "games" {
+		t.Errorf("Category = %q, want %q", got.Category, "games")
+	}
+}
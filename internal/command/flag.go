@@ -2,17 +2,16 @@ package command
 
 import (
 	"errors"
-	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/spf13/pflag"
+
 	"github.com/fyne-io/fyne-cross/internal/volume"
 )
 
-var flagSet = flag.NewFlagSet("fyne-cross", flag.ExitOnError)
-
 // CommonFlags holds the flags shared between all commands
 type CommonFlags struct {
 	// AppID represents the application ID used for distribution
@@ -44,10 +43,19 @@ type CommonFlags struct {
 	Debug bool
 	// Pull attempts to pull a newer version of the docker image
 	Pull bool
+	// Format is the output format used to report build results: "text"
+	// (default), "json" or "template=<go template>"
+	Format string
+	// IIDFile, if set, is a path to write the SHA-256 of every built artifact
+	// to, one per line
+	IIDFile string
 }
 
-// newCommonFlags defines all the flags for the shared options
-func newCommonFlags() (*CommonFlags, error) {
+// newCommonFlags binds CommonFlags onto fs, so the caller can register them
+// either as a command's local flags or, for the root command, as persistent
+// flags inherited by every target. Shorthands follow Docker/Podman CLI
+// conventions (-D debug, -o output, -i icon, -t tags).
+func newCommonFlags(fs *pflag.FlagSet) (*CommonFlags, error) {
 	output, err := defaultOutput()
 	if err != nil {
 		return nil, err
@@ -67,20 +75,22 @@ func newCommonFlags() (*CommonFlags, error) {
 	}
 
 	flags := &CommonFlags{}
-	flagSet.StringVar(&flags.AppID, "app-id", output, "Application ID used for distribution")
-	flagSet.StringVar(&flags.CacheDir, "cache", cacheDir, "Directory used to share/cache sources and dependencies")
-	flagSet.BoolVar(&flags.NoCache, "no-cache", false, "Do not use the go build cache")
-	flagSet.Var(&flags.Env, "env", "List of additional env variables specified as KEY=VALUE and separated by comma")
-	flagSet.StringVar(&flags.Icon, "icon", defaultIcon, "Application icon used for distribution")
-	flagSet.StringVar(&flags.DockerImage, "image", "", "Custom docker image to use for build")
-	flagSet.StringVar(&flags.Ldflags, "ldflags", "", "Additional flags to pass to the external linker")
-	flagSet.Var(&flags.Tags, "tags", "List of additional build tags separated by comma")
-	flagSet.BoolVar(&flags.NoStripDebug, "no-strip-debug", false, "Do not strip debug information from binaries")
-	flagSet.StringVar(&flags.Output, "output", output, "Named output file")
-	flagSet.StringVar(&flags.RootDir, "dir", rootDir, "Fyne app root directory")
-	flagSet.BoolVar(&flags.Silent, "silent", false, "Silent mode")
-	flagSet.BoolVar(&flags.Debug, "debug", false, "Debug mode")
-	flagSet.BoolVar(&flags.Pull, "pull", false, "Attempt to pull a newer version of the docker image")
+	fs.StringVar(&flags.AppID, "app-id", output, "Application ID used for distribution")
+	fs.StringVar(&flags.CacheDir, "cache", cacheDir, "Directory used to share/cache sources and dependencies")
+	fs.BoolVar(&flags.NoCache, "no-cache", false, "Do not use the go build cache")
+	fs.Var(&flags.Env, "env", "List of additional env variables specified as KEY=VALUE and separated by comma")
+	fs.StringVarP(&flags.Icon, "icon", "i", defaultIcon, "Application icon used for distribution")
+	fs.StringVar(&flags.DockerImage, "image", "", "Custom docker image to use for build")
+	fs.StringVar(&flags.Ldflags, "ldflags", "", "Additional flags to pass to the external linker")
+	fs.VarP(&flags.Tags, "tags", "t", "List of additional build tags separated by comma")
+	fs.BoolVar(&flags.NoStripDebug, "no-strip-debug", false, "Do not strip debug information from binaries")
+	fs.StringVarP(&flags.Output, "output", "o", output, "Named output file")
+	fs.StringVar(&flags.RootDir, "dir", rootDir, "Fyne app root directory")
+	fs.BoolVar(&flags.Silent, "silent", false, "Silent mode")
+	fs.BoolVarP(&flags.Debug, "debug", "D", false, "Debug mode")
+	fs.BoolVar(&flags.Pull, "pull", false, "Attempt to pull a newer version of the docker image")
+	fs.StringVar(&flags.Format, "format", formatText, `Output format for build results: "text", "json" or "template=<go template>"`)
+	fs.StringVar(&flags.IIDFile, "iidfile", "", "Write the SHA-256 of every built artifact to this file, one per line")
 	return flags, nil
 }
 
@@ -93,35 +103,120 @@ func defaultOutput() (string, error) {
 	return output, nil
 }
 
+// commonFlagEnvVars maps a common flag name to the environment variable
+// fyne-cross falls back to when the flag was not explicitly set on the
+// command line, so CI systems can drive builds without composing long argv.
+var commonFlagEnvVars = map[string]string{
+	"app-id":  "FYNE_CROSS_APP_ID",
+	"cache":   "FYNE_CROSS_CACHE_DIR",
+	"env":     "FYNE_CROSS_ENV",
+	"icon":    "FYNE_CROSS_ICON",
+	"image":   "FYNE_CROSS_DOCKER_IMAGE",
+	"ldflags": "FYNE_CROSS_LDFLAGS",
+	"tags":    "FYNE_CROSS_TAGS",
+	"output":  "FYNE_CROSS_OUTPUT",
+	"dir":     "FYNE_CROSS_DIR",
+	"format":  "FYNE_CROSS_FORMAT",
+}
+
+// applyEnvFallback walks every flag registered on fs and, for each one that
+// was not explicitly passed on the command line, applies the value of its
+// matching environment variable from commonFlagEnvVars, if set. It must be
+// called after fs.Parse (cobra does this in PersistentPreRunE): telling an
+// explicit flag apart from one left at its default value relies on
+// fs.Visit, which only reports flags that were set.
+func applyEnvFallback(fs *pflag.FlagSet) error {
+	explicit := make(map[string]bool)
+	fs.Visit(func(f *pflag.Flag) {
+		explicit[f.Name] = true
+	})
+
+	var err error
+	fs.VisitAll(func(f *pflag.Flag) {
+		if err != nil || explicit[f.Name] {
+			return
+		}
+		envVar, ok := commonFlagEnvVars[f.Name]
+		if !ok {
+			return
+		}
+		if value, ok := os.LookupEnv(envVar); ok {
+			if setErr := fs.Set(f.Name, value); setErr != nil {
+				err = fmt.Errorf("invalid value for --%s from env var %s: %w", f.Name, envVar, setErr)
+			}
+		}
+	})
+	return err
+}
+
+// explicitCommonFlags returns a *CommonFlags holding only the fields whose
+// backing flag on fs was actually changed on the command line (per
+// fs.Changed), leaving every other field at its zero value. newCommonFlags'
+// defaults (AppID, Icon, Output, CacheDir, ...) are always non-empty, even
+// when the user never passed the flag, so callers that need to distinguish
+// "explicitly set" from "left at its default" - such as Definition.Expand's
+// CLI-overrides-win-over-manifest merge - must use this instead of flags
+// itself.
+func explicitCommonFlags(fs *pflag.FlagSet, flags *CommonFlags) *CommonFlags {
+	explicit := &CommonFlags{}
+	changed := func(name string) bool {
+		return fs.Changed(name)
+	}
+
+	if changed("app-id") {
+		explicit.AppID = flags.AppID
+	}
+	if changed("cache") {
+		explicit.CacheDir = flags.CacheDir
+	}
+	if changed("image") {
+		explicit.DockerImage = flags.DockerImage
+	}
+	if changed("env") {
+		explicit.Env = flags.Env
+	}
+	if changed("icon") {
+		explicit.Icon = flags.Icon
+	}
+	if changed("ldflags") {
+		explicit.Ldflags = flags.Ldflags
+	}
+	if changed("tags") {
+		explicit.Tags = flags.Tags
+	}
+	if changed("output") {
+		explicit.Output = flags.Output
+	}
+	return explicit
+}
+
 // envFlag is a custom flag used to define custom env variables
 type envFlag []string
 
-// String is the method to format the flag's value, part of the flag.Value interface.
+// String is the method to format the flag's value, part of the pflag.Value interface.
 // The String method's output will be used in diagnostics.
 func (ef *envFlag) String() string {
 	return fmt.Sprint(*ef)
 }
 
-// Set is the method to set the flag value, part of the flag.Value interface.
+// Type returns the flag's type name, part of the pflag.Value interface.
+func (ef *envFlag) Type() string {
+	return "stringArray"
+}
+
+// Set is the method to set the flag value, part of the pflag.Value interface.
 // Set's argument is a string to be parsed to set the flag.
-// It's a comma-separated list, so we split it.
+// It's a comma-separated list, so we split it. Unlike flag.FlagSet's built-in
+// types, repeated occurrences of the flag append to the existing value
+// instead of replacing it, so "--env A=1 --env B=2" keeps both.
 func (ef *envFlag) Set(value string) error {
-	*ef = []string{}
-	if len(*ef) > 1 {
-		return errors.New("flag already set")
-	}
-
 	for _, v := range strings.Split(value, ",") {
-
-		*ef = append(*ef, v)
-	}
-
-	// validate env vars
-	for _, v := range *ef {
 		parts := strings.Split(v, "=")
 		if len(parts) != 2 {
 			return errors.New("env var must defined as KEY=VALUE or KEY=")
 		}
+
+		*ef = append(*ef, v)
 	}
 
 	return nil
@@ -130,21 +225,22 @@ func (ef *envFlag) Set(value string) error {
 // targetArchFlag is a custom flag used to define architectures
 type targetArchFlag []string
 
-// String is the method to format the flag's value, part of the flag.Value interface.
+// String is the method to format the flag's value, part of the pflag.Value interface.
 // The String method's output will be used in diagnostics.
 func (af *targetArchFlag) String() string {
 	return fmt.Sprint(*af)
 }
 
-// Set is the method to set the flag value, part of the flag.Value interface.
+// Type returns the flag's type name, part of the pflag.Value interface.
+func (af *targetArchFlag) Type() string {
+	return "stringArray"
+}
+
+// Set is the method to set the flag value, part of the pflag.Value interface.
 // Set's argument is a string to be parsed to set the flag.
-// It's a comma-separated list, so we split it.
+// It's a comma-separated list, so we split it. Repeated occurrences of the
+// flag append to the existing value instead of replacing it.
 func (af *targetArchFlag) Set(value string) error {
-	*af = []string{}
-	if len(*af) > 1 {
-		return errors.New("flag already set")
-	}
-
 	for _, v := range strings.Split(value, ",") {
 		*af = append(*af, strings.TrimSpace(v))
 	}
@@ -154,21 +250,22 @@ func (af *targetArchFlag) Set(value string) error {
 // tagsFlag is a custom flag used to define build tags
 type tagsFlag []string
 
-// String is the method to format the flag's value, part of the flag.Value interface.
+// String is the method to format the flag's value, part of the pflag.Value interface.
 // The String method's output will be used in diagnostics.
 func (tf *tagsFlag) String() string {
 	return fmt.Sprint(*tf)
 }
 
-// Set is the method to set the flag value, part of the flag.Value interface.
+// Type returns the flag's type name, part of the pflag.Value interface.
+func (tf *tagsFlag) Type() string {
+	return "stringArray"
+}
+
+// Set is the method to set the flag value, part of the pflag.Value interface.
 // Set's argument is a string to be parsed to set the flag.
-// It's a comma-separated list, so we split it.
+// It's a comma-separated list, so we split it. Repeated occurrences of the
+// flag append to the existing value instead of replacing it.
 func (tf *tagsFlag) Set(value string) error {
-	*tf = []string{}
-	if len(*tf) > 1 {
-		return errors.New("flag already set")
-	}
-
 	for _, v := range strings.Split(value, ",") {
 		*tf = append(*tf, strings.TrimSpace(v))
 	}
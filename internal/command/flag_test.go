@@ -0,0 +1,98 @@
+package command
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func TestEnvFlagSetAppends(t *testing.T) {
+	cases := []struct {
+		name   string
+		values []string
+		want   []string
+	}{
+		{"single", []string{"A=1"}, []string{"A=1"}},
+		{"repeated", []string{"A=1", "B=2"}, []string{"A=1", "B=2"}},
+		{"comma separated", []string{"A=1,B=2"}, []string{"A=1", "B=2"}},
+		{"repeated and comma separated", []string{"A=1,B=2", "C=3"}, []string{"A=1", "B=2", "C=3"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var ef envFlag
+			for _, v := range c.values {
+				if err := ef.Set(v); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			if !reflect.DeepEqual([]string(ef), c.want) {
+				t.Errorf("got %v, want %v", []string(ef), c.want)
+			}
+		})
+	}
+}
+
+func TestEnvFlagSetInvalid(t *testing.T) {
+	var ef envFlag
+	if err := ef.Set("NOTKEYVALUE"); err == nil {
+		t.Error("expected error for malformed env var, got nil")
+	}
+}
+
+func TestTagsFlagSetAppends(t *testing.T) {
+	var tf tagsFlag
+	if err := tf.Set("a, b"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := tf.Set("c"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"a", "b", "c"}
+	if !reflect.DeepEqual([]string(tf), want) {
+		t.Errorf("got %v, want %v", []string(tf), want)
+	}
+}
+
+func TestTargetArchFlagSetAppends(t *testing.T) {
+	var af targetArchFlag
+	if err := af.Set("amd64"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := af.Set("arm64, 386"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"amd64", "arm64", "386"}
+	if !reflect.DeepEqual([]string(af), want) {
+		t.Errorf("got %v, want %v", []string(af), want)
+	}
+}
+
+func TestApplyEnvFallback(t *testing.T) {
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	var appID, explicitIcon string
+	fs.StringVar(&appID, "app-id", "default-app-id", "")
+	fs.StringVar(&explicitIcon, "icon", "default-icon", "")
+
+	t.Setenv("FYNE_CROSS_APP_ID", "env-app-id")
+	t.Setenv("FYNE_CROSS_ICON", "env-icon")
+
+	if err := fs.Parse([]string{"--icon", "flag-icon"}); err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	if err := applyEnvFallback(fs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// app-id was not passed on the command line, so the env var applies.
+	if appID != "env-app-id" {
+		t.Errorf("app-id = %q, want %q", appID, "env-app-id")
+	}
+	// icon was explicitly set on the command line, so it must win over the
+	// env var, even though both are set.
+	if explicitIcon != "flag-icon" {
+		t.Errorf("icon = %q, want %q", explicitIcon, "flag-icon")
+	}
+}
@@ -0,0 +1,96 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// formatText and formatJSON are the two well-known values the --format flag
+// accepts; besides them only a "template=..." Go template is accepted, and
+// WriteResults rejects any other value.
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
+// BuildResult describes a single produced artifact. It is emitted as a JSON
+// document (or Go-template rendering) at the end of a build when --format
+// is "json" or "template=...", so CI can inspect what was built without
+// scraping log output.
+type BuildResult struct {
+	Target      string   `json:"target"`
+	Arch        string   `json:"arch"`
+	OutputPath  string   `json:"outputPath"`
+	Size        int64    `json:"size"`
+	SHA256      string   `json:"sha256"`
+	DockerImage string   `json:"dockerImage,omitempty"`
+	DurationMS  int64    `json:"durationMs"`
+	Ldflags     string   `json:"ldflags,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	AppID       string   `json:"appId,omitempty"`
+	Icon        string   `json:"icon,omitempty"`
+	// Certificate, Profile and Category are the code-signing/packaging
+	// overrides from the manifest target that produced this result, if any.
+	Certificate string `json:"certificate,omitempty"`
+	Profile     string `json:"profile,omitempty"`
+	Category    string `json:"category,omitempty"`
+}
+
+// WriteResults renders results to w according to format:
+//   - "text" (or empty) prints one human-readable line per result.
+//   - "json" encodes results as a single JSON array.
+//   - "template=..." renders results through the given Go template.
+//
+// In "json" and "template=..." mode this is the only thing fyne-cross
+// writes to stdout, so "fyne-cross linux --format json | jq" stays
+// pipe-clean; progress output is expected to already be going to stderr.
+func WriteResults(w io.Writer, format string, results []*BuildResult) error {
+	switch {
+	case format == "" || format == formatText:
+		for _, r := range results {
+			fmt.Fprintf(w, "%s/%s -> %s (sha256:%s)\n", r.Target, r.Arch, r.OutputPath, r.SHA256)
+		}
+		return nil
+	case format == formatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(results)
+	case strings.HasPrefix(format, "template="):
+		tmpl, err := template.New("format").Parse(strings.TrimPrefix(format, "template="))
+		if err != nil {
+			return fmt.Errorf("invalid --format template: %w", err)
+		}
+		return tmpl.Execute(w, results)
+	default:
+		return fmt.Errorf("unsupported --format %q: expected text, json or template=...", format)
+	}
+}
+
+// WriteIIDFile writes the SHA-256 of every produced artifact to path, one
+// per line, mirroring buildah's --iidfile.
+func WriteIIDFile(path string, results []*BuildResult) error {
+	var b strings.Builder
+	for _, r := range results {
+		b.WriteString(r.SHA256)
+		b.WriteString("\n")
+	}
+	return os.WriteFile(path, []byte(b.String()), 0644)
+}
+
+// emitResults writes the build results to cmd's stdout in the format
+// requested by flags.Format, and to flags.IIDFile if set.
+func emitResults(out io.Writer, flags *CommonFlags, results []*BuildResult) error {
+	if err := WriteResults(out, flags.Format, results); err != nil {
+		return err
+	}
+	if flags.IIDFile != "" {
+		if err := WriteIIDFile(flags.IIDFile, results); err != nil {
+			return err
+		}
+	}
+	return nil
+}
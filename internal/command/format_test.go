@@ -0,0 +1,108 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func sampleResults() []*BuildResult {
+	return []*BuildResult{
+		{
+			Target:     "linux",
+			Arch:       "amd64",
+			OutputPath: "/tmp/myapp",
+			Size:       1024,
+			SHA256:     "aaaa",
+		},
+		{
+			Target:     "windows",
+			Arch:       "amd64",
+			OutputPath: "/tmp/myapp.exe",
+			Size:       2048,
+			SHA256:     "bbbb",
+		},
+	}
+}
+
+func TestWriteResultsText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteResults(&buf, "", sampleResults()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "linux/amd64") || !strings.Contains(out, "/tmp/myapp") {
+		t.Errorf("text output missing linux result, got %q", out)
+	}
+	if !strings.Contains(out, "windows/amd64") || !strings.Contains(out, "/tmp/myapp.exe") {
+		t.Errorf("text output missing windows result, got %q", out)
+	}
+}
+
+func TestWriteResultsJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteResults(&buf, formatJSON, sampleResults()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got []*BuildResult
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d results, want 2", len(got))
+	}
+	if got[0].Target != "linux" || got[1].Target != "windows" {
+		t.Errorf("got targets %q, %q, want linux, windows", got[0].Target, got[1].Target)
+	}
+}
+
+func TestWriteResultsTemplate(t *testing.T) {
+	var buf bytes.Buffer
+	format := "template=" + `{{range .}}{{.Target}}:{{.SHA256}} {{end}}`
+	if err := WriteResults(&buf, format, sampleResults()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "linux:aaaa windows:bbbb "
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestWriteResultsInvalidFormat(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteResults(&buf, "xml", sampleResults()); err == nil {
+		t.Error("expected an error for an unsupported format, got nil")
+	}
+}
+
+func TestWriteResultsInvalidTemplate(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteResults(&buf, "template={{", sampleResults()); err == nil {
+		t.Error("expected an error for a malformed template, got nil")
+	}
+}
+
+func TestWriteIIDFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "iid")
+
+	if err := WriteIIDFile(path, sampleResults()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "aaaa\nbbbb\n"
+	if string(data) != want {
+		t.Errorf("got %q, want %q", string(data), want)
+	}
+}
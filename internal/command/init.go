@@ -0,0 +1,78 @@
+package command
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/fyne-io/fyne-cross/internal/volume"
+)
+
+// newInitCommand builds the "init" cobra command, which writes a starter
+// fyne-cross manifest for the current working directory.
+func newInitCommand() *cobra.Command {
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Write a starter fyne-cross manifest for this project",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return writeStarterDefinition(file)
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "fyne-cross.yaml", "Path to write the manifest to")
+	_ = cmd.MarkFlagFilename("file", "yaml", "yml", "toml")
+
+	return cmd
+}
+
+// writeStarterDefinition infers AppID, output and icon the same way
+// newCommonFlags does and writes them as a single linux target manifest,
+// encoded as TOML when path ends in .toml and as YAML otherwise.
+func writeStarterDefinition(path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists", path)
+	}
+
+	output, err := defaultOutput()
+	if err != nil {
+		return err
+	}
+	icon, err := volume.DefaultIconHost()
+	if err != nil {
+		return err
+	}
+
+	def := &Definition{
+		Common: CommonDefinition{
+			AppID: output,
+			Icon:  icon,
+		},
+		Targets: []TargetDefinition{
+			{Platform: "linux", Output: output},
+		},
+	}
+
+	var data []byte
+	if strings.ToLower(filepath.Ext(path)) == ".toml" {
+		var buf bytes.Buffer
+		if err := toml.NewEncoder(&buf).Encode(def); err != nil {
+			return fmt.Errorf("cannot marshal starter manifest: %w", err)
+		}
+		data = buf.Bytes()
+	} else {
+		data, err = yaml.Marshal(def)
+		if err != nil {
+			return fmt.Errorf("cannot marshal starter manifest: %w", err)
+		}
+	}
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,245 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+// supportedManifestPlatforms lists the target names a Definition may
+// reference. It is a subset of the target commands fyne-cross ships: the
+// freebsd, netbsd and openbsd commands cannot be used in a manifest.
+var supportedManifestPlatforms = map[string]bool{
+	"linux":   true,
+	"windows": true,
+	"darwin":  true,
+	"android": true,
+	"ios":     true,
+	"web":     true,
+}
+
+// Definition describes a full cross-build matrix loaded from a
+// fyne-cross.yaml or fyne-cross.toml manifest. Common holds the fields
+// shared by every target, matching the relevant subset of CommonFlags;
+// Targets lists the platforms to build and any per-target overrides.
+type Definition struct {
+	Common  CommonDefinition   `yaml:"common" toml:"common"`
+	Targets []TargetDefinition `yaml:"targets" toml:"targets"`
+}
+
+// CommonDefinition holds the manifest fields shared by every target. It is
+// merged into CommonFlags before any per-target overrides are applied.
+type CommonDefinition struct {
+	AppID       string   `yaml:"app-id,omitempty" toml:"app-id,omitempty"`
+	Icon        string   `yaml:"icon,omitempty" toml:"icon,omitempty"`
+	Ldflags     string   `yaml:"ldflags,omitempty" toml:"ldflags,omitempty"`
+	Tags        []string `yaml:"tags,omitempty" toml:"tags,omitempty"`
+	Env         []string `yaml:"env,omitempty" toml:"env,omitempty"`
+	CacheDir    string   `yaml:"cache,omitempty" toml:"cache,omitempty"`
+	DockerImage string   `yaml:"image,omitempty" toml:"image,omitempty"`
+}
+
+// TargetDefinition describes a single platform entry in a Definition,
+// together with the fields it may override from CommonDefinition.
+type TargetDefinition struct {
+	// Platform is one of the keys in supportedManifestPlatforms.
+	Platform string   `yaml:"platform" toml:"platform"`
+	Arch     []string `yaml:"arch,omitempty" toml:"arch,omitempty"`
+	Output   string   `yaml:"output,omitempty" toml:"output,omitempty"`
+	Env      []string `yaml:"env,omitempty" toml:"env,omitempty"`
+	Ldflags  string   `yaml:"ldflags,omitempty" toml:"ldflags,omitempty"`
+	Tags     []string `yaml:"tags,omitempty" toml:"tags,omitempty"`
+
+	// Certificate and Profile name the code-signing certificate and profile
+	// for android/ios/darwin artifacts. They are copied onto the target's
+	// BuildResult; no signing step is run.
+	Certificate string `yaml:"certificate,omitempty" toml:"certificate,omitempty"`
+	Profile     string `yaml:"profile,omitempty" toml:"profile,omitempty"`
+
+	// Category is packaging metadata, copied onto the target's BuildResult.
+	Category string `yaml:"category,omitempty" toml:"category,omitempty"`
+}
+
+// LoadDefinition reads and decodes the manifest at path, then validates it.
+// The file extension (.yaml, .yml or .toml) selects the decoder. Unknown
+// keys are rejected so that typos in a target block fail loudly instead of
+// being silently ignored.
+func LoadDefinition(path string) (*Definition, error) {
+	def := &Definition{}
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("cannot open manifest %q: %w", path, err)
+		}
+		defer f.Close()
+
+		dec := yaml.NewDecoder(f)
+		dec.KnownFields(true)
+		if err := dec.Decode(def); err != nil {
+			return nil, fmt.Errorf("cannot parse manifest %q: %w", path, err)
+		}
+	case ".toml":
+		meta, err := toml.DecodeFile(path, def)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse manifest %q: %w", path, err)
+		}
+		if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+			return nil, fmt.Errorf("unknown key %q in manifest %q", undecoded[0].String(), path)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported manifest extension %q: expected .yaml, .yml or .toml", ext)
+	}
+
+	if err := def.Validate(); err != nil {
+		return nil, err
+	}
+	return def, nil
+}
+
+// Validate checks that the Definition declares at least one target, that
+// every target names a supported platform and that no platform is
+// declared more than once.
+func (d *Definition) Validate() error {
+	if len(d.Targets) == 0 {
+		return errors.New("manifest must declare at least one target")
+	}
+
+	seen := make(map[string]bool, len(d.Targets))
+	for i, t := range d.Targets {
+		if !supportedManifestPlatforms[t.Platform] {
+			return fmt.Errorf("targets[%d]: unsupported platform %q", i, t.Platform)
+		}
+		if seen[t.Platform] {
+			return fmt.Errorf("targets[%d]: platform %q declared more than once", i, t.Platform)
+		}
+		seen[t.Platform] = true
+	}
+	return nil
+}
+
+// ExpandedTarget is a single platform's build ready to run, produced by
+// Expand; it covers every arch listed in Arch. Certificate, Profile and Category carry the code-signing and
+// packaging metadata from the target's manifest entry, since they have no
+// equivalent in CommonFlags.
+type ExpandedTarget struct {
+	Platform    string
+	Arch        []string
+	Flags       *CommonFlags
+	Certificate string
+	Profile     string
+	Category    string
+}
+
+// Expand merges the Definition into one ExpandedTarget per entry in
+// d.Targets, following the precedence built-in defaults -> common block ->
+// per-target block -> CLI flag overrides. overrides may be nil, in which
+// case only the manifest values apply.
+func (d *Definition) Expand(overrides *CommonFlags) ([]*ExpandedTarget, error) {
+	base, err := newCommonFlags(pflag.NewFlagSet("manifest", pflag.ContinueOnError))
+	if err != nil {
+		return nil, err
+	}
+	applyCommonDefinition(base, d.Common)
+
+	targets := make([]*ExpandedTarget, 0, len(d.Targets))
+	for _, t := range d.Targets {
+		flags := *base
+		applyTargetDefinition(&flags, t)
+		applyFlagOverrides(&flags, overrides)
+
+		targets = append(targets, &ExpandedTarget{
+			Platform:    t.Platform,
+			Arch:        t.Arch,
+			Flags:       &flags,
+			Certificate: t.Certificate,
+			Profile:     t.Profile,
+			Category:    t.Category,
+		})
+	}
+	return targets, nil
+}
+
+// applyCommonDefinition overlays the manifest's common block onto flags,
+// leaving built-in defaults in place for anything left unset.
+func applyCommonDefinition(flags *CommonFlags, c CommonDefinition) {
+	if c.AppID != "" {
+		flags.AppID = c.AppID
+	}
+	if c.Icon != "" {
+		flags.Icon = c.Icon
+	}
+	if c.Ldflags != "" {
+		flags.Ldflags = c.Ldflags
+	}
+	if len(c.Tags) > 0 {
+		flags.Tags = tagsFlag(c.Tags)
+	}
+	if len(c.Env) > 0 {
+		flags.Env = envFlag(c.Env)
+	}
+	if c.CacheDir != "" {
+		flags.CacheDir = c.CacheDir
+	}
+	if c.DockerImage != "" {
+		flags.DockerImage = c.DockerImage
+	}
+}
+
+// applyTargetDefinition overlays a single target's overrides onto flags.
+func applyTargetDefinition(flags *CommonFlags, t TargetDefinition) {
+	if t.Output != "" {
+		flags.Output = t.Output
+	}
+	if t.Ldflags != "" {
+		flags.Ldflags = t.Ldflags
+	}
+	if len(t.Tags) > 0 {
+		flags.Tags = tagsFlag(t.Tags)
+	}
+	if len(t.Env) > 0 {
+		flags.Env = envFlag(t.Env)
+	}
+}
+
+// applyFlagOverrides overlays any flags explicitly passed on the command
+// line, which always win over the manifest. overrides may be nil, and must
+// only carry the fields that were actually set on the command line (see
+// explicitCommonFlags) - a full newCommonFlags() result would have every
+// field non-empty and would permanently shadow the manifest.
+func applyFlagOverrides(flags *CommonFlags, overrides *CommonFlags) {
+	if overrides == nil {
+		return
+	}
+	if overrides.AppID != "" {
+		flags.AppID = overrides.AppID
+	}
+	if overrides.Icon != "" {
+		flags.Icon = overrides.Icon
+	}
+	if overrides.Ldflags != "" {
+		flags.Ldflags = overrides.Ldflags
+	}
+	if len(overrides.Tags) > 0 {
+		flags.Tags = overrides.Tags
+	}
+	if len(overrides.Env) > 0 {
+		flags.Env = overrides.Env
+	}
+	if overrides.CacheDir != "" {
+		flags.CacheDir = overrides.CacheDir
+	}
+	if overrides.DockerImage != "" {
+		flags.DockerImage = overrides.DockerImage
+	}
+	if overrides.Output != "" {
+		flags.Output = overrides.Output
+	}
+}
@@ -0,0 +1,235 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func TestDefinitionValidate(t *testing.T) {
+	cases := []struct {
+		name    string
+		def     Definition
+		wantErr bool
+	}{
+		{"no targets", Definition{}, true},
+		{"unsupported platform", Definition{Targets: []TargetDefinition{{Platform: "amiga"}}}, true},
+		{"duplicate platform", Definition{Targets: []TargetDefinition{{Platform: "linux"}, {Platform: "linux"}}}, true},
+		{"valid", Definition{Targets: []TargetDefinition{{Platform: "linux"}, {Platform: "windows"}}}, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.def.Validate()
+			if (err != nil) != c.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, c.wantErr)
+			}
+		})
+	}
+}
+
+func TestDefinitionExpandPrecedence(t *testing.T) {
+	def := &Definition{
+		Common: CommonDefinition{
+			AppID:   "common-app-id",
+			Ldflags: "-X common=1",
+			Tags:    []string{"common-tag"},
+		},
+		Targets: []TargetDefinition{
+			{
+				Platform: "linux",
+				Output:   "target-output",
+				Tags:     []string{"target-tag"},
+			},
+			{
+				Platform: "windows",
+				// inherits everything from the common block
+			},
+		},
+	}
+
+	t.Run("target overrides common", func(t *testing.T) {
+		targets, err := def.Expand(nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(targets) != 2 {
+			t.Fatalf("got %d targets, want 2", len(targets))
+		}
+
+		linux := targets[0]
+		if linux.Flags.AppID != "common-app-id" {
+			t.Errorf("linux AppID = %q, want inherited %q", linux.Flags.AppID, "common-app-id")
+		}
+		if linux.Flags.Output != "target-output" {
+			t.Errorf("linux Output = %q, want target override %q", linux.Flags.Output, "target-output")
+		}
+		if len(linux.Flags.Tags) != 1 || linux.Flags.Tags[0] != "target-tag" {
+			t.Errorf("linux Tags = %v, want target override to replace common tags", linux.Flags.Tags)
+		}
+
+		windows := targets[1]
+		if windows.Flags.Ldflags != "-X common=1" {
+			t.Errorf("windows Ldflags = %q, want inherited common value", windows.Flags.Ldflags)
+		}
+	})
+
+	t.Run("cli flag overrides win over manifest", func(t *testing.T) {
+		overrides := &CommonFlags{AppID: "cli-app-id"}
+		targets, err := def.Expand(overrides)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, target := range targets {
+			if target.Flags.AppID != "cli-app-id" {
+				t.Errorf("%s AppID = %q, want CLI override %q", target.Platform, target.Flags.AppID, "cli-app-id")
+			}
+		}
+	})
+}
+
+// TestDefinitionExpandAgainstRealCommonFlags guards against a real
+// newCommonFlags() result (whose AppID/Icon/Output/CacheDir are always
+// non-empty cwd/home-derived defaults, flag or no flag) being passed to
+// Expand unfiltered: every manifest value would then be permanently
+// shadowed by those defaults. explicitCommonFlags must strip out whatever
+// wasn't actually passed on the command line first.
+func TestDefinitionExpandAgainstRealCommonFlags(t *testing.T) {
+	def := &Definition{
+		Common: CommonDefinition{
+			AppID: "manifest-app-id",
+			Icon:  "manifest-icon.png",
+		},
+		Targets: []TargetDefinition{
+			{Platform: "linux", Output: "/tmp/manifest-out"},
+		},
+	}
+
+	t.Run("no flags passed: manifest values survive", func(t *testing.T) {
+		fs := pflag.NewFlagSet("fyne-cross", pflag.ContinueOnError)
+		flags, err := newCommonFlags(fs)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := fs.Parse(nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		targets, err := def.Expand(explicitCommonFlags(fs, flags))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		got := targets[0].Flags
+		if got.AppID != "manifest-app-id" {
+			t.Errorf("AppID = %q, want manifest value %q (not the cwd-derived default)", got.AppID, "manifest-app-id")
+		}
+		if got.Icon != "manifest-icon.png" {
+			t.Errorf("Icon = %q, want manifest value %q (not the default Icon.png path)", got.Icon, "manifest-icon.png")
+		}
+		if got.Output != "/tmp/manifest-out" {
+			t.Errorf("Output = %q, want manifest value %q", got.Output, "/tmp/manifest-out")
+		}
+	})
+
+	t.Run("flag explicitly passed: it wins over the manifest", func(t *testing.T) {
+		fs := pflag.NewFlagSet("fyne-cross", pflag.ContinueOnError)
+		flags, err := newCommonFlags(fs)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := fs.Parse([]string{"--app-id", "cli-app-id"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		targets, err := def.Expand(explicitCommonFlags(fs, flags))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		got := targets[0].Flags
+		if got.AppID != "cli-app-id" {
+			t.Errorf("AppID = %q, want explicit CLI value %q", got.AppID, "cli-app-id")
+		}
+		if got.Icon != "manifest-icon.png" {
+			t.Errorf("Icon = %q, want manifest value %q to survive since --icon was not passed", got.Icon, "manifest-icon.png")
+		}
+	})
+}
+
+// TestDefinitionExpandCarriesSigningAndPackagingMetadata guards against the
+// per-target Certificate/Profile/Category fields being parsed and validated
+// but then silently dropped during Expand.
+func TestDefinitionExpandCarriesSigningAndPackagingMetadata(t *testing.T) {
+	def := &Definition{
+		Targets: []TargetDefinition{
+			{
+				Platform:    "android",
+				Certificate: "release.keystore",
+				Profile:     "release-profile",
+				Category:    "games",
+			},
+		},
+	}
+
+	targets, err := def.Expand(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(targets) != 1 {
+		t.Fatalf("got %d targets, want 1", len(targets))
+	}
+
+	got := targets[0]
+	if got.Certificate != "release.keystore" {
+		t.Errorf("Certificate = %q, want %q", got.Certificate, "release.keystore")
+	}
+	if got.Profile != "release-profile" {
+		t.Errorf("Profile = %q, want %q", got.Profile, "release-profile")
+	}
+	if got.Category != "games" {
+		t.Errorf("Category = %q, want %q", got.Category, "games")
+	}
+}
+
+func TestLoadDefinitionRejectsUnknownKeys(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "fyne-cross.yaml")
+	content := []byte("targets:\n  - platform: linux\n    bogus-key: true\n")
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := LoadDefinition(path); err == nil {
+		t.Error("expected an error for an unknown manifest key, got nil")
+	}
+}
+
+func TestLoadDefinitionYAMLRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "fyne-cross.yaml")
+	content := []byte(`common:
+  app-id: com.example.app
+targets:
+  - platform: ios
+    certificate: dist.p12
+    profile: dist-profile
+    category: productivity
+`)
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	def, err := LoadDefinition(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if def.Common.AppID != "com.example.app" {
+		t.Errorf("Common.AppID = %q, want %q", def.Common.AppID, "com.example.app")
+	}
+	if len(def.Targets) != 1 || def.Targets[0].Certificate != "dist.p12" {
+		t.Errorf("Targets = %+v, want a single ios target with certificate dist.p12", def.Targets)
+	}
+}
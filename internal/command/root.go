@@ -0,0 +1,96 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// targetPlatforms lists the platform-specific commands fyne-cross ships as
+// children of the root command.
+var targetPlatforms = []string{
+	"linux", "windows", "darwin", "android", "ios", "web",
+	"freebsd", "netbsd", "openbsd",
+}
+
+// TargetMetadata carries the per-build fields that have no equivalent in
+// CommonFlags: the architecture to build for (defaulting to the runner's
+// own choice when empty) and the code-signing/packaging overrides a
+// manifest target may set.
+type TargetMetadata struct {
+	Arch        string
+	Certificate string
+	Profile     string
+	Category    string
+}
+
+// runPlatform is populated by runner.go's init with the function that
+// actually runs a build for each platform, keyed by platform name.
+var runPlatform = map[string]func(flags *CommonFlags, pkg string, meta TargetMetadata) (*BuildResult, error){}
+
+// NewRootCommand builds the root "fyne-cross" cobra command. CommonFlags
+// are bound once as persistent flags so every subcommand inherits them
+// (including the ergonomic shorthands such as -D/-o/-i/-t), and every
+// existing target plus the manifest "build"/"init" commands are registered
+// as children. The built-in "completion" subcommand is available for free.
+func NewRootCommand() (*cobra.Command, error) {
+	root := &cobra.Command{
+		Use:   "fyne-cross",
+		Short: "Compile your Fyne application for multiple operating systems",
+	}
+
+	flags, err := newCommonFlags(root.PersistentFlags())
+	if err != nil {
+		return nil, err
+	}
+
+	root.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		return applyEnvFallback(cmd.Flags())
+	}
+
+	if err := root.MarkPersistentFlagDirname("dir"); err != nil {
+		return nil, err
+	}
+	if err := root.MarkPersistentFlagDirname("cache"); err != nil {
+		return nil, err
+	}
+	if err := root.MarkPersistentFlagFilename("icon"); err != nil {
+		return nil, err
+	}
+
+	for _, platform := range targetPlatforms {
+		root.AddCommand(newTargetCommand(platform, flags))
+	}
+	root.AddCommand(newBuildCommand(flags))
+	root.AddCommand(newInitCommand())
+
+	return root, nil
+}
+
+// newTargetCommand builds the cobra.Command for a single platform target
+// (linux, windows, darwin, ...). It preserves the old positional package
+// argument, e.g. "fyne-cross linux ./cmd/app", as the command's only
+// positional argument.
+func newTargetCommand(platform string, flags *CommonFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:   platform + " [package]",
+		Short: fmt.Sprintf("Compile the Fyne application for the %s OS", platform),
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pkg := "."
+			if len(args) == 1 {
+				pkg = args[0]
+			}
+
+			run, ok := runPlatform[platform]
+			if !ok {
+				return fmt.Errorf("no runner registered for platform %q", platform)
+			}
+			result, err := run(flags, pkg, TargetMetadata{})
+			if err != nil {
+				return err
+			}
+			return emitResults(cmd.OutOrStdout(), flags, []*BuildResult{result})
+		},
+	}
+}
@@ -0,0 +1,134 @@
+package command
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// goosForPlatform maps a fyne-cross platform name to the GOOS value used to
+// cross compile for it with the host Go toolchain. android, ios and web are
+// deliberately absent: those targets need the gomobile/gowasm toolchains
+// rather than a plain "go build" and are registered separately below with
+// an error explaining that.
+var goosForPlatform = map[string]string{
+	"linux":   "linux",
+	"windows": "windows",
+	"darwin":  "darwin",
+	"freebsd": "freebsd",
+	"netbsd":  "netbsd",
+	"openbsd": "openbsd",
+}
+
+func init() {
+	for platform, goos := range goosForPlatform {
+		platform, goos := platform, goos
+		runPlatform[platform] = func(flags *CommonFlags, pkg string, meta TargetMetadata) (*BuildResult, error) {
+			return runGoBuild(platform, goos, flags, pkg, meta)
+		}
+	}
+
+	for _, platform := range []string{"android", "ios", "web"} {
+		platform := platform
+		runPlatform[platform] = func(flags *CommonFlags, pkg string, meta TargetMetadata) (*BuildResult, error) {
+			return nil, fmt.Errorf("%s builds require the platform-specific toolchain (gomobile/gowasm), which is not wired up yet", platform)
+		}
+	}
+}
+
+// defaultArch is used when meta.Arch is empty, i.e. the caller did not name
+// an architecture (the CLI target commands don't have an --arch flag yet;
+// only manifest targets can set one).
+const defaultArch = "amd64"
+
+// runGoBuild cross compiles pkg for platform using the host Go toolchain
+// (GOOS=goos, GOARCH=meta.Arch), applying the ldflags/tags/env from flags,
+// and returns a BuildResult describing the produced binary. meta.Certificate/
+// Profile/Category are carried onto the result as-is: fyne-cross does not
+// yet perform the platform-specific signing/packaging step itself.
+// flags.NoCache, RootDir, CacheDir, DockerImage and Pull are not used: go
+// runs in the current directory with the host's own build cache.
+func runGoBuild(platform, goos string, flags *CommonFlags, pkg string, meta TargetMetadata) (*BuildResult, error) {
+	start := time.Now()
+
+	arch := meta.Arch
+	if arch == "" {
+		arch = defaultArch
+	}
+
+	output := flags.Output
+	if platform == "windows" {
+		output += ".exe"
+	}
+	outputPath, err := filepath.Abs(output)
+	if err != nil {
+		return nil, fmt.Errorf("cannot resolve output path: %w", err)
+	}
+
+	ldflags := flags.Ldflags
+	if !flags.NoStripDebug {
+		ldflags = strings.TrimSpace("-s -w " + ldflags)
+	}
+
+	args := []string{"build", "-o", outputPath}
+	if len(flags.Tags) > 0 {
+		args = append(args, "-tags", strings.Join(flags.Tags, ","))
+	}
+	if ldflags != "" {
+		args = append(args, "-ldflags", ldflags)
+	}
+	args = append(args, pkg)
+
+	cmd := exec.Command("go", args...)
+	cmd.Env = append(os.Environ(), "GOOS="+goos, "GOARCH="+arch)
+	cmd.Env = append(cmd.Env, flags.Env...)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("go build failed for %s/%s: %w", goos, arch, err)
+	}
+
+	sum, size, err := sha256File(outputPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BuildResult{
+		Target:      platform,
+		Arch:        arch,
+		OutputPath:  outputPath,
+		Size:        size,
+		SHA256:      sum,
+		DurationMS:  time.Since(start).Milliseconds(),
+		Ldflags:     ldflags,
+		Tags:        flags.Tags,
+		AppID:       flags.AppID,
+		Icon:        flags.Icon,
+		Certificate: meta.Certificate,
+		Profile:     meta.Profile,
+		Category:    meta.Category,
+	}, nil
+}
+
+// sha256File returns the hex-encoded SHA-256 and size in bytes of the file
+// at path.
+func sha256File(path string) (string, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
@@ -0,0 +1,37 @@
+// Package volume resolves the host-side paths fyne-cross needs before it
+// can even parse its flags: the project root, the build cache directory,
+// and a default application icon.
+package volume
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// DefaultWorkDirHost returns the project root directory on the host,
+// defaulting to the current working directory.
+func DefaultWorkDirHost() (string, error) {
+	return os.Getwd()
+}
+
+// DefaultCacheDirHost returns the directory used to share/cache sources and
+// dependencies between builds, defaulting to the user's cache directory
+// (i.e. $HOME/.cache/fyne-cross).
+func DefaultCacheDirHost() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "fyne-cross"), nil
+}
+
+// DefaultIconHost returns the path to the application icon used for
+// distribution when none is specified, defaulting to "Icon.png" in the
+// current working directory.
+func DefaultIconHost() (string, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(wd, "Icon.png"), nil
+}